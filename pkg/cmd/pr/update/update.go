@@ -3,12 +3,20 @@ package update
 import (
 	"context"
 	"fmt"
+	"io"
 	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"text/tabwriter"
+	"time"
 
 	"github.com/MakeNowJust/heredoc"
 	"github.com/cli/cli/v2/api"
 	ghContext "github.com/cli/cli/v2/context"
 	"github.com/cli/cli/v2/git"
+	"github.com/cli/cli/v2/internal/ghrepo"
 	shared "github.com/cli/cli/v2/pkg/cmd/pr/shared"
 	"github.com/cli/cli/v2/pkg/cmdutil"
 	"github.com/cli/cli/v2/pkg/iostreams"
@@ -22,15 +30,31 @@ type UpdateOptions struct {
 	GitClient  *git.Client
 	Remotes    func() (ghContext.Remotes, error)
 	Branch     func() (string, error)
+	BaseRepo   func() (ghrepo.Interface, error)
 
 	Finder   shared.PRFinder
 	Prompter shared.EditPrompter
 
 	SelectorArg string
+	Selectors   []string
 	Interactive bool
 	SkipLocal   bool
 	UpdateLocal bool
 	Rebase      bool
+	FastForward bool
+
+	AuthorFilter string
+	LabelFilter  string
+	SearchFilter string
+	All          bool
+	Jobs         int
+
+	Autostash bool
+
+	DryRun bool
+	Force  bool
+
+	Push bool
 }
 
 func NewCmdUpdate(f *cmdutil.Factory, runF func(*UpdateOptions) error) *cobra.Command {
@@ -40,17 +64,26 @@ func NewCmdUpdate(f *cmdutil.Factory, runF func(*UpdateOptions) error) *cobra.Co
 		GitClient:  f.GitClient,
 		Remotes:    f.Remotes,
 		Branch:     f.Branch,
+		BaseRepo:   f.BaseRepo,
 		Prompter:   f.Prompter,
 	}
 
 	cmd := &cobra.Command{
-		Use:   "update [<number> | <url> | <branch>]",
+		Use:   "update [<number> | <url> | <branch> ...]",
 		Short: "Update a pull request branch",
 		Long: heredoc.Docf(`
 			Update a pull request branch with latest changes of the base branch.
 
 			Without an argument, the pull request that belongs to the current branch is selected.
 
+			More than one pull request can be updated in a single invocation by passing multiple
+			selectors, or by selecting a set of pull requests with %[1]s--author%[1]s,
+			%[1]s--label%[1]s, %[1]s--search%[1]s, or %[1]s--all%[1]s (every open pull request in the
+			current repository that is behind its base branch). Batches are processed concurrently,
+			bounded by %[1]s--jobs%[1]s, and a summary of successes and failures is printed to
+			stderr. Local-branch handling is skipped for any pull request whose head branch does
+			not match the branch the command was run on.
+
 			The default behavior is to update with a merge (i.e., merging the base branch into the
 			the PR's branch). To reconcile the changes with rebasing on top of the base branch the
 			%[1]s--rebase%[1]s option should be provided.
@@ -58,23 +91,69 @@ func NewCmdUpdate(f *cmdutil.Factory, runF func(*UpdateOptions) error) *cobra.Co
 			If the current local branch tracks the PR branch, the command will prompt for pulling
 			the latest changes. To skip the prompt, either one of %[1]s--update-local%[1]s or
 			%[1]s--skip-local%[1]s options should be provided.
-			
+
 			In non-interactive mode, the command will not update the local branch.
+
+			Uncommitted local changes are stashed and restored around the local update when
+			%[1]s--autostash%[1]s is set, or when the %[1]srebase.autoStash%[1]s /
+			%[1]spull.autoStash%[1]s git config is enabled. If restoring the stash after the update
+			conflicts, the stash is left in place and the conflict is reported.
+
+			%[1]s--dry-run%[1]s reports the PR's current mergeable state (%[1]sBEHIND%[1]s,
+			%[1]sCLEAN%[1]s, %[1]sCONFLICTING%[1]s, or %[1]sUNKNOWN%[1]s) and, when it can be
+			computed from the local repository, how many commits the base branch is ahead,
+			without updating anything. Outside of %[1]s--dry-run%[1]s, a
+			%[1]sCONFLICTING%[1]s state is refused up front with guidance to resolve it via
+			%[1]sgh pr checkout%[1]s, instead of surfacing the underlying GraphQL error; pass
+			%[1]s--force%[1]s to attempt the update anyway.
+
+			%[1]s--push%[1]s updates the PR branch locally and pushes the result, instead of calling
+			the API. This is useful when the PR branch lives on a fork the viewer cannot write to
+			through the normal update API. It prefers an AGit-flow push
+			(%[1]sHEAD:refs/for/<base>%[1]s with %[1]stopic%[1]s/%[1]spr%[1]s push options) when the
+			remote advertises AGit support, and falls back to a normal push to the PR branch
+			otherwise.
+
+			The %[1]s--fast-forward%[1]s (or %[1]s--ff-only%[1]s) option replaces the local update
+			step with a fast-forward-only ref update: the latest PR branch is fetched and the local
+			branch is advanced to it without creating a merge commit. If the local branch has
+			diverged and cannot be fast-forwarded, the command aborts instead of merging. Together
+			with %[1]s--rebase%[1]s, it replaces the warning that a manual pull is required: the
+			local branch is advanced to the newly rebased remote head only if its current head is
+			an ancestor of it, and the command aborts otherwise; a local branch whose commits were
+			rewritten by the rebase is not reset.
 		`, "`"),
 		Example: heredoc.Doc(`
 			$ gh pr update 23"
 			$ gh pr update 23 --update-local"
 			$ gh pr update 23 --skip-local"
 			$ gh pr update 23 --rebase"
+			$ gh pr update 23 --rebase --fast-forward"
+			$ gh pr update 23 41 https://github.com/OWNER/REPO/pull/57"
+			$ gh pr update --author @me --jobs 8"
+			$ gh pr update --all"
 		`),
-		Args: cobra.MaximumNArgs(1),
+		Args: cobra.ArbitraryArgs,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			opts.Finder = shared.NewFinder(f)
+			opts.Selectors = args
 
-			if len(args) > 0 {
+			if !cmd.Flags().Changed("autostash") && opts.GitClient != nil {
+				opts.Autostash = gitConfigAutostash(context.Background(), opts)
+			}
+
+			if len(args) == 1 {
 				opts.SelectorArg = args[0]
 			}
 
+			if err := cmdutil.MutuallyExclusive(
+				"specify only one of pull request selectors or `--author`, `--label`, `--search`, `--all`",
+				len(args) > 0,
+				opts.AuthorFilter != "" || opts.LabelFilter != "" || opts.SearchFilter != "" || opts.All,
+			); err != nil {
+				return err
+			}
+
 			if err := cmdutil.MutuallyExclusive(
 				"specify only one of `--skip-local` or `--update-local`",
 				opts.SkipLocal,
@@ -102,6 +181,18 @@ func NewCmdUpdate(f *cmdutil.Factory, runF func(*UpdateOptions) error) *cobra.Co
 	cmd.Flags().BoolVarP(&opts.SkipLocal, "skip-local", "s", false, "Do not update local branch")
 	cmd.Flags().BoolVarP(&opts.UpdateLocal, "update-local", "u", false, "Update local branch")
 	cmd.Flags().BoolVar(&opts.Rebase, "rebase", false, "Update PR branch by rebasing on top of latest base branch")
+	cmd.Flags().BoolVar(&opts.FastForward, "fast-forward", false, "Update local branch with a fast-forward-only ref update instead of `git pull`")
+	cmd.Flags().BoolVar(&opts.FastForward, "ff-only", false, "Alias of `--fast-forward`")
+	_ = cmd.Flags().MarkHidden("ff-only")
+	cmd.Flags().StringVar(&opts.AuthorFilter, "author", "", "Update pull requests authored by user (use `@me` for yourself)")
+	cmd.Flags().StringVar(&opts.LabelFilter, "label", "", "Update pull requests with the given label")
+	cmd.Flags().StringVar(&opts.SearchFilter, "search", "", "Update pull requests matching the given search query")
+	cmd.Flags().BoolVar(&opts.All, "all", false, "Update all open pull requests in the current repository that are behind their base branch")
+	cmd.Flags().IntVar(&opts.Jobs, "jobs", 4, "Number of pull requests to update concurrently in batch mode")
+	cmd.Flags().BoolVar(&opts.Autostash, "autostash", false, "Stash uncommitted local changes before updating the local branch, then restore them")
+	cmd.Flags().BoolVar(&opts.DryRun, "dry-run", false, "Report the PR's mergeable state without updating anything")
+	cmd.Flags().BoolVar(&opts.Force, "force", false, "Attempt the update even if the PR has conflicts")
+	cmd.Flags().BoolVar(&opts.Push, "push", false, "Update the PR branch locally and push it, instead of using the update API (for forks the viewer cannot write to)")
 
 	_ = cmdutil.RegisterBranchCompletionFlags(f.GitClient, cmd, "base")
 
@@ -109,17 +200,43 @@ func NewCmdUpdate(f *cmdutil.Factory, runF func(*UpdateOptions) error) *cobra.Co
 }
 
 func updateRun(opts *UpdateOptions) error {
-	ctx := context.Background()
+	if isBatchMode(opts) {
+		return updateRunBatch(opts)
+	}
+
+	if len(opts.Selectors) == 1 {
+		opts.SelectorArg = opts.Selectors[0]
+	}
+
+	return updateRunSingle(opts)
+}
+
+// isBatchMode reports whether the invocation targets more than one pull request,
+// either via multiple selectors or via one of the filter flags.
+func isBatchMode(opts *UpdateOptions) bool {
+	return len(opts.Selectors) > 1 ||
+		opts.All ||
+		opts.AuthorFilter != "" ||
+		opts.LabelFilter != "" ||
+		opts.SearchFilter != ""
+}
 
+func updateRunSingle(opts *UpdateOptions) error {
 	findOptions := shared.FindOptions{
 		Selector: opts.SelectorArg,
-		Fields:   []string{"id", "headRefName", "headRefOid"},
+		Fields:   []string{"id", "number", "headRefName", "headRefOid", "baseRefName", "baseRefOid", "mergeable", "mergeStateStatus", "isCrossRepository", "headRepositoryOwner", "headRepository"},
 	}
 	pr, repo, err := opts.Finder.Find(findOptions)
 	if err != nil {
 		return err
 	}
 
+	return performUpdate(opts, pr, repo)
+}
+
+func performUpdate(opts *UpdateOptions, pr *api.PullRequest, repo ghrepo.Interface) error {
+	ctx := context.Background()
+
 	currentBranch, err := opts.Branch()
 	if err != nil {
 		return err
@@ -138,6 +255,20 @@ func updateRun(opts *UpdateOptions) error {
 		}
 	}
 
+	if opts.DryRun {
+		return reportMergeState(opts, pr)
+	}
+
+	if !opts.Force {
+		if state := classifyMergeState(pr); state == mergeStateConflicting {
+			return fmt.Errorf("PR #%d has conflicts with its base branch and cannot be updated automatically; run `gh pr checkout %d`, merge or rebase the base branch locally, then push, or pass `--force` to attempt the update anyway", pr.Number, pr.Number)
+		}
+	}
+
+	if opts.Push {
+		return pushUpdate(opts, pr, repo)
+	}
+
 	updateMethod := githubv4.PullRequestBranchUpdateMethodMerge
 	if opts.Rebase {
 		updateMethod = githubv4.PullRequestBranchUpdateMethodRebase
@@ -154,7 +285,7 @@ func updateRun(opts *UpdateOptions) error {
 	}
 	apiClient := api.NewClientFromHTTP(httpClient)
 
-	updatedHeadRefOid, err := api.UpdatePullRequestBranch(apiClient, repo, params)
+	updatedHeadRefOid, err := updatePullRequestBranchWithBackoff(apiClient, repo, params)
 	if err != nil {
 		return err
 	}
@@ -166,12 +297,20 @@ func updateRun(opts *UpdateOptions) error {
 
 	fmt.Fprintf(opts.IO.ErrOut, "%s PR branch updated\n", cs.SuccessIcon())
 
-	if opts.Rebase && opts.GitClient.HasLocalBranch(ctx, pr.HeadRefName) {
+	rebaseWithLocalBranch := opts.Rebase && opts.GitClient.HasLocalBranch(ctx, pr.HeadRefName)
+
+	if rebaseWithLocalBranch && !opts.FastForward {
 		fmt.Fprintf(opts.IO.ErrOut, "%s warning: due to rebase, you need to manually pull the latest changes to the local branch\n", cs.WarningIcon())
 		return nil
 	}
 
-	if opts.Rebase || opts.SkipLocal || !opts.Interactive && !opts.UpdateLocal {
+	localUpdateRequested := opts.UpdateLocal
+
+	if opts.Rebase && !rebaseWithLocalBranch {
+		return nil
+	}
+
+	if opts.SkipLocal || !opts.Interactive && !localUpdateRequested {
 		return nil
 	}
 
@@ -181,7 +320,7 @@ func updateRun(opts *UpdateOptions) error {
 		return nil
 	}
 
-	if opts.Interactive && !opts.UpdateLocal {
+	if opts.Interactive && !localUpdateRequested {
 		if !opts.IO.CanPrompt() {
 			return nil
 		}
@@ -205,7 +344,12 @@ func updateRun(opts *UpdateOptions) error {
 		return err
 	}
 
-	if err := opts.GitClient.Pull(context.Background(), remote.Name, pr.HeadRefName); err != nil {
+	if opts.FastForward {
+		if err := fastForwardLocalBranch(ctx, opts, remote.Name, pr.HeadRefName); err != nil {
+			fmt.Fprintf(opts.IO.ErrOut, "%s cannot fast-forward local branch\n", cs.FailureIcon())
+			return err
+		}
+	} else if err := pullWithAutostash(ctx, opts, remote.Name, pr.HeadRefName, cs); err != nil {
 		fmt.Fprintf(opts.IO.ErrOut, "%s cannot update local branch\n", cs.FailureIcon())
 		return err
 	}
@@ -214,3 +358,477 @@ func updateRun(opts *UpdateOptions) error {
 
 	return nil
 }
+
+// fastForwardLocalBranch advances the local PR branch to match the remote without
+// creating a merge commit: fetch the PR branch, confirm the current local head is
+// an ancestor of the fetched commit, and only then fast-forward onto it. It
+// refuses to proceed, naming the diverging commits, rather than falling back to a
+// merge.
+//
+// This is only ever called on the currently checked-out branch (see the caller in
+// performUpdate), so the fast-forward itself is done with `git merge --ff-only`,
+// which updates the working tree and index along with the ref, rather than moving
+// the ref alone.
+func fastForwardLocalBranch(ctx context.Context, opts *UpdateOptions, remote, branch string) error {
+	if err := gitRun(ctx, opts, "fetch", remote, branch); err != nil {
+		return err
+	}
+
+	localOid, err := gitOutput(ctx, opts, "rev-parse", "refs/heads/"+branch)
+	if err != nil {
+		return err
+	}
+
+	fetchedOid, err := gitOutput(ctx, opts, "rev-parse", "FETCH_HEAD")
+	if err != nil {
+		return err
+	}
+
+	if localOid == fetchedOid {
+		return nil
+	}
+
+	if err := gitRun(ctx, opts, "merge-base", "--is-ancestor", localOid, fetchedOid); err != nil {
+		return fmt.Errorf("local branch has diverged from the PR branch (local %s, remote %s); fast-forward is not possible", shortOid(localOid), shortOid(fetchedOid))
+	}
+
+	return gitRun(ctx, opts, "merge", "--ff-only", fetchedOid)
+}
+
+func shortOid(oid string) string {
+	if len(oid) > 8 {
+		return oid[:8]
+	}
+	return oid
+}
+
+// gitOutput runs git through the shared GitClient and returns trimmed stdout, the
+// same low-level primitive Pull/HasLocalBranch are themselves built on.
+func gitOutput(ctx context.Context, opts *UpdateOptions, args ...string) (string, error) {
+	cmd, err := opts.GitClient.Command(ctx, args...)
+	if err != nil {
+		return "", err
+	}
+	out, err := cmd.Output()
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(string(out)), nil
+}
+
+// gitRun is like gitOutput but for commands whose output isn't needed.
+func gitRun(ctx context.Context, opts *UpdateOptions, args ...string) error {
+	cmd, err := opts.GitClient.Command(ctx, args...)
+	if err != nil {
+		return err
+	}
+	return cmd.Run()
+}
+
+// gitConfigAutostash reports whether the user has enabled `rebase.autoStash` or
+// `pull.autoStash`, used as the default for `--autostash` when the flag is not
+// explicitly set.
+func gitConfigAutostash(ctx context.Context, opts *UpdateOptions) bool {
+	for _, key := range []string{"rebase.autoStash", "pull.autoStash"} {
+		// --type=bool makes git itself normalize its full boolean vocabulary
+		// (yes/no, on/off, true/false, 1/0) down to "true"/"false".
+		value, err := gitOutput(ctx, opts, "config", "--type=bool", "--get", key)
+		if err != nil {
+			continue
+		}
+		if value == "true" {
+			return true
+		}
+	}
+	return false
+}
+
+// pullWithAutostash runs `git pull`, stashing and restoring uncommitted local
+// changes around it when `--autostash` is set. A pop conflict leaves the stash
+// in place so the user can resolve it manually, rather than discarding it.
+func pullWithAutostash(ctx context.Context, opts *UpdateOptions, remote, branch string, cs *iostreams.ColorScheme) error {
+	if !opts.Autostash {
+		return opts.GitClient.Pull(ctx, remote, branch)
+	}
+
+	changeCount, err := opts.GitClient.UncommittedChangeCount(ctx)
+	if err != nil {
+		return err
+	}
+	if changeCount == 0 {
+		return opts.GitClient.Pull(ctx, remote, branch)
+	}
+
+	stashMessage := fmt.Sprintf("gh-pr-update-autostash-%d", time.Now().Unix())
+	if err := gitRun(ctx, opts, "stash", "push", "--include-untracked", "-m", stashMessage); err != nil {
+		return fmt.Errorf("could not stash uncommitted changes: %w", err)
+	}
+
+	pullErr := opts.GitClient.Pull(ctx, remote, branch)
+
+	if popErr := gitRun(ctx, opts, "stash", "pop"); popErr != nil {
+		fmt.Fprintf(opts.IO.ErrOut, "%s could not restore stashed changes; run `git stash pop` manually: %s\n", cs.WarningIcon(), popErr)
+		return pullErr
+	}
+
+	return pullErr
+}
+
+// batchResult records the outcome of updating a single pull request as part of
+// a batch invocation.
+type batchResult struct {
+	pr  *api.PullRequest
+	err error
+}
+
+func updateRunBatch(opts *UpdateOptions) error {
+	httpClient, err := opts.HttpClient()
+	if err != nil {
+		return err
+	}
+	apiClient := api.NewClientFromHTTP(httpClient)
+
+	prs, repo, err := resolveBatch(opts, apiClient)
+	if err != nil {
+		return err
+	}
+
+	if len(prs) == 0 {
+		fmt.Fprintln(opts.IO.ErrOut, "no pull requests matched")
+		return nil
+	}
+
+	currentBranch, err := opts.Branch()
+	if err != nil {
+		return err
+	}
+
+	jobs := opts.Jobs
+	if jobs < 1 {
+		jobs = 4
+	}
+
+	results := make([]batchResult, len(prs))
+	sem := make(chan struct{}, jobs)
+	var wg sync.WaitGroup
+
+	// performUpdate writes directly to opts.IO.Out/ErrOut; since it now runs
+	// concurrently across up to opts.Jobs goroutines, route every PR's output
+	// through the same mutex-guarded writer so those writes don't race.
+	syncOut := &syncWriter{w: opts.IO.Out}
+	syncErr := &syncWriter{w: opts.IO.ErrOut}
+
+	for i, pr := range prs {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(i int, pr *api.PullRequest) {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			prOpts := *opts
+			prIO := *opts.IO
+			prIO.Out = syncOut
+			prIO.ErrOut = syncErr
+			prOpts.IO = &prIO
+			if pr.HeadRefName != currentBranch {
+				prOpts.UpdateLocal = false
+				prOpts.SkipLocal = true
+				prOpts.Interactive = false
+			}
+
+			results[i] = batchResult{pr: pr, err: performUpdate(&prOpts, pr, repo)}
+		}(i, pr)
+	}
+	wg.Wait()
+
+	return reportBatchResults(opts, results)
+}
+
+// syncWriter serializes writes from concurrent goroutines onto a shared
+// io.Writer, so batch mode's concurrent performUpdate calls don't race on
+// the underlying terminal or buffer.
+type syncWriter struct {
+	mu sync.Mutex
+	w  io.Writer
+}
+
+func (s *syncWriter) Write(p []byte) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.w.Write(p)
+}
+
+// resolveBatch resolves the set of pull requests targeted by a batch invocation,
+// either from the explicit selectors passed on the command line or from the
+// filter flags (`--author`, `--label`, `--search`, `--all`).
+func resolveBatch(opts *UpdateOptions, apiClient *api.Client) ([]*api.PullRequest, ghrepo.Interface, error) {
+	fields := []string{"id", "number", "headRefName", "headRefOid", "baseRefName", "baseRefOid", "mergeable", "mergeStateStatus", "isCrossRepository", "headRepositoryOwner", "headRepository"}
+
+	if len(opts.Selectors) > 0 {
+		var prs []*api.PullRequest
+		var repo ghrepo.Interface
+		for _, selector := range opts.Selectors {
+			pr, prRepo, err := opts.Finder.Find(shared.FindOptions{Selector: selector, Fields: fields})
+			if err != nil {
+				return nil, nil, fmt.Errorf("failed to find pull request %q: %w", selector, err)
+			}
+			repo = prRepo
+			prs = append(prs, pr)
+		}
+		return prs, repo, nil
+	}
+
+	repo, err := opts.BaseRepo()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	query := buildBatchSearchQuery(opts)
+	prs, err := api.PullRequestSearch(apiClient, repo, query, fields)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return prs, repo, nil
+}
+
+func buildBatchSearchQuery(opts *UpdateOptions) string {
+	parts := []string{"is:open", "is:pr"}
+	if opts.All {
+		parts = append(parts, "mergeable-state:behind")
+	}
+	if opts.AuthorFilter != "" {
+		parts = append(parts, "author:"+opts.AuthorFilter)
+	}
+	if opts.LabelFilter != "" {
+		parts = append(parts, fmt.Sprintf("label:%q", opts.LabelFilter))
+	}
+	if opts.SearchFilter != "" {
+		parts = append(parts, opts.SearchFilter)
+	}
+	return strings.Join(parts, " ")
+}
+
+// reportBatchResults prints a per-PR summary table to stderr and returns a
+// non-nil error if any pull request failed to update, so the command exits
+// non-zero without duplicating the already-reported failures.
+func reportBatchResults(opts *UpdateOptions, results []batchResult) error {
+	cs := opts.IO.ColorScheme()
+
+	w := tabwriter.NewWriter(opts.IO.ErrOut, 0, 0, 2, ' ', 0)
+	failed := 0
+	for _, result := range results {
+		status := fmt.Sprintf("%s updated", cs.SuccessIcon())
+		if result.err != nil {
+			failed++
+			status = fmt.Sprintf("%s %s", cs.FailureIcon(), result.err)
+		}
+		fmt.Fprintf(w, "#%d\t%s\t%s\n", result.pr.Number, result.pr.HeadRefName, status)
+	}
+	_ = w.Flush()
+
+	fmt.Fprintf(opts.IO.ErrOut, "%d updated, %d failed\n", len(results)-failed, failed)
+
+	if failed > 0 {
+		return cmdutil.SilentError
+	}
+
+	return nil
+}
+
+// updatePullRequestBranchWithBackoff retries api.UpdatePullRequestBranch with
+// exponential backoff when GitHub returns a primary or secondary rate-limit
+// error, instead of letting a single throttled request abort an entire batch.
+func updatePullRequestBranchWithBackoff(apiClient *api.Client, repo ghrepo.Interface, params githubv4.UpdatePullRequestBranchInput) (string, error) {
+	const maxAttempts = 5
+	backoff := time.Second
+
+	var lastErr error
+	for attempt := 0; attempt < maxAttempts; attempt++ {
+		oid, err := api.UpdatePullRequestBranch(apiClient, repo, params)
+		if err == nil {
+			return oid, nil
+		}
+		if !isRateLimitError(err) {
+			return "", err
+		}
+		lastErr = err
+		time.Sleep(backoff)
+		backoff *= 2
+	}
+
+	return "", lastErr
+}
+
+func isRateLimitError(err error) bool {
+	msg := strings.ToLower(err.Error())
+	return strings.Contains(msg, "rate limit") || strings.Contains(msg, "secondary rate limit")
+}
+
+const (
+	mergeStateBehind      = "BEHIND"
+	mergeStateClean       = "CLEAN"
+	mergeStateConflicting = "CONFLICTING"
+	mergeStateUnknown     = "UNKNOWN"
+)
+
+// classifyMergeState collapses the PR's `mergeable`/`mergeStateStatus` GraphQL
+// fields down to the four states the command surfaces in `--dry-run` output
+// and pre-flight conflict checks.
+func classifyMergeState(pr *api.PullRequest) string {
+	switch strings.ToUpper(pr.MergeStateStatus) {
+	case "BEHIND":
+		return mergeStateBehind
+	case "CLEAN":
+		return mergeStateClean
+	case "DIRTY", "CONFLICTING":
+		return mergeStateConflicting
+	}
+
+	if strings.ToUpper(pr.Mergeable) == "CONFLICTING" {
+		return mergeStateConflicting
+	}
+
+	return mergeStateUnknown
+}
+
+// commitDelta reports how many commits the PR's base branch is ahead of the
+// merge base with the PR branch, or -1 if it cannot be determined locally
+// (e.g. the base commit hasn't been fetched yet).
+func commitDelta(opts *UpdateOptions, pr *api.PullRequest) int {
+	if pr.BaseRefOid == "" || pr.HeadRefOid == "" {
+		return -1
+	}
+
+	out, err := gitOutput(context.Background(), opts, "rev-list", "--count", pr.HeadRefOid+".."+pr.BaseRefOid)
+	if err != nil {
+		return -1
+	}
+
+	count, err := strconv.Atoi(out)
+	if err != nil {
+		return -1
+	}
+
+	return count
+}
+
+// reportMergeState prints the PR's mergeable state and base-ahead commit
+// count for `--dry-run`, without mutating anything.
+func reportMergeState(opts *UpdateOptions, pr *api.PullRequest) error {
+	state := classifyMergeState(pr)
+	fmt.Fprintf(opts.IO.Out, "state: %s\n", state)
+
+	if delta := commitDelta(opts, pr); delta >= 0 {
+		fmt.Fprintf(opts.IO.Out, "commits behind base: %d\n", delta)
+	}
+
+	return nil
+}
+
+// pushHeadRepo returns the repository that owns the PR's head branch. For a
+// same-repository PR that's just repo; for a PR from a fork, it's the fork,
+// derived from the PR's head repository fields rather than assumed to be repo.
+func pushHeadRepo(pr *api.PullRequest, repo ghrepo.Interface) ghrepo.Interface {
+	if !pr.IsCrossRepository {
+		return repo
+	}
+	return ghrepo.NewWithHost(pr.HeadRepositoryOwner.Login, pr.HeadRepository.Name, repo.RepoHost())
+}
+
+// pushUpdate updates the PR branch entirely locally and pushes the result,
+// for PRs whose head lives on a fork the viewer cannot write to through
+// `updatePullRequestBranch`. It reconciles onto a temporary branch in an
+// isolated git worktree, built from the PR's current head fetched from the
+// head repo's own remote (which, for a forked PR, is the fork, not the base
+// repo), so the merge or rebase never touches whatever branch happens to be
+// checked out in the user's actual working tree. It then prefers an
+// AGit-flow push (`HEAD:refs/for/<base>` with `topic`/`pr` push options) when
+// the head remote advertises AGit support, falling back to a normal push to
+// the PR branch when it does not.
+func pushUpdate(opts *UpdateOptions, pr *api.PullRequest, repo ghrepo.Interface) error {
+	ctx := context.Background()
+	cs := opts.IO.ColorScheme()
+
+	remotes, err := opts.Remotes()
+	if err != nil {
+		return err
+	}
+	baseRemote, err := remotes.FindByRepo(repo.RepoOwner(), repo.RepoName())
+	if err != nil {
+		return fmt.Errorf("could not find a remote for %s; add one before using `--push`: %w", ghrepo.FullName(repo), err)
+	}
+
+	headRepo := pushHeadRepo(pr, repo)
+	headRemote := baseRemote
+	if pr.IsCrossRepository {
+		headRemote, err = remotes.FindByRepo(headRepo.RepoOwner(), headRepo.RepoName())
+		if err != nil {
+			return fmt.Errorf("could not find a remote for %s; add one before using `--push`: %w", ghrepo.FullName(headRepo), err)
+		}
+	}
+
+	// Fetch the PR's head commit from its own remote before anything else, since
+	// for a forked PR it typically isn't in the local object database yet.
+	if err := gitRun(ctx, opts, "fetch", headRemote.Name, pr.HeadRefName); err != nil {
+		return err
+	}
+
+	worktreeDir, err := os.MkdirTemp("", "gh-pr-update-")
+	if err != nil {
+		return err
+	}
+	defer func() { _ = os.RemoveAll(worktreeDir) }()
+
+	if err := gitRun(ctx, opts, "worktree", "add", "--detach", worktreeDir, pr.HeadRefOid); err != nil {
+		return err
+	}
+	defer func() { _ = gitRun(ctx, opts, "worktree", "remove", "--force", worktreeDir) }()
+
+	tempBranch := fmt.Sprintf("gh-pr-update-%d", pr.Number)
+	if err := gitRun(ctx, opts, "-C", worktreeDir, "checkout", "-B", tempBranch); err != nil {
+		return err
+	}
+
+	// Fetch from inside the worktree, since FETCH_HEAD is kept per worktree.
+	if err := gitRun(ctx, opts, "-C", worktreeDir, "fetch", baseRemote.Name, pr.BaseRefName); err != nil {
+		return err
+	}
+
+	if opts.Rebase {
+		if err := gitRun(ctx, opts, "-C", worktreeDir, "rebase", "FETCH_HEAD"); err != nil {
+			return fmt.Errorf("could not rebase onto the base branch; resolve the conflicts locally and push manually: %w", err)
+		}
+	} else if err := gitRun(ctx, opts, "-C", worktreeDir, "merge", "FETCH_HEAD"); err != nil {
+		return fmt.Errorf("could not merge the base branch; resolve the conflicts locally and push manually: %w", err)
+	}
+
+	if remoteSupportsAgit(ctx, opts, headRemote.Name) {
+		refspec := "HEAD:refs/for/" + pr.BaseRefName
+		if err := gitRun(ctx, opts, "-C", worktreeDir, "push", headRemote.Name, refspec,
+			"-o", "topic="+pr.HeadRefName, "-o", fmt.Sprintf("pr=%d", pr.Number)); err != nil {
+			return fmt.Errorf("agit-style push failed: %w", err)
+		}
+		fmt.Fprintf(opts.IO.ErrOut, "%s pushed update via AGit\n", cs.SuccessIcon())
+		return nil
+	}
+
+	if err := gitRun(ctx, opts, "-C", worktreeDir, "push", headRemote.Name, "HEAD:"+pr.HeadRefName); err != nil {
+		return fmt.Errorf("the remote does not advertise AGit support and a normal push to %q was rejected; push manually with write access to the fork, or ask the PR author to update the branch: %w", pr.HeadRefName, err)
+	}
+
+	fmt.Fprintf(opts.IO.ErrOut, "%s pushed update\n", cs.SuccessIcon())
+	return nil
+}
+
+// remoteSupportsAgit probes the remote for AGit-flow support by listing its HEAD
+// with `git ls-remote --symref` and checking whether that output mentions
+// `refs/for/`. Only the lines for HEAD are examined; the remote's other refs are
+// not listed.
+func remoteSupportsAgit(ctx context.Context, opts *UpdateOptions, remote string) bool {
+	refs, err := gitOutput(ctx, opts, "ls-remote", "--symref", remote, "HEAD")
+	if err != nil {
+		return false
+	}
+	return strings.Contains(refs, "refs/for/")
+}
@@ -101,6 +101,84 @@ func TestNewCmdUpdate(t *testing.T) {
 				Rebase:      true,
 			},
 		},
+		{
+			name:  "no argument, --autostash",
+			input: "--autostash",
+			output: UpdateOptions{
+				Interactive: true,
+				Autostash:   true,
+			},
+		},
+		{
+			name:  "no argument, --dry-run",
+			input: "--dry-run",
+			output: UpdateOptions{
+				Interactive: true,
+				DryRun:      true,
+			},
+		},
+		{
+			name:  "no argument, --force",
+			input: "--force",
+			output: UpdateOptions{
+				Interactive: true,
+				Force:       true,
+			},
+		},
+		{
+			name:  "no argument, --push",
+			input: "--push",
+			output: UpdateOptions{
+				Interactive: true,
+				Push:        true,
+			},
+		},
+		{
+			name:  "no argument, --rebase --fast-forward",
+			input: "--rebase --fast-forward",
+			output: UpdateOptions{
+				Interactive: true,
+				Rebase:      true,
+				FastForward: true,
+			},
+		},
+		{
+			name:  "no argument, --ff-only",
+			input: "--ff-only",
+			output: UpdateOptions{
+				Interactive: true,
+				FastForward: true,
+			},
+		},
+		{
+			name:  "multiple selectors",
+			input: "23 41",
+			output: UpdateOptions{
+				Interactive: true,
+				Selectors:   []string{"23", "41"},
+			},
+		},
+		{
+			name:  "no argument, --author",
+			input: "--author @me",
+			output: UpdateOptions{
+				Interactive:  true,
+				AuthorFilter: "@me",
+			},
+		},
+		{
+			name:  "no argument, --all",
+			input: "--all",
+			output: UpdateOptions{
+				Interactive: true,
+				All:         true,
+			},
+		},
+		{
+			name:     "mutually exclusive options: selectors and --all",
+			input:    "23 --all",
+			wantsErr: "specify only one of pull request selectors or `--author`, `--label`, `--search`, `--all`",
+		},
 		{
 			name:     "mutually exclusive options: --rebase and --update-local",
 			input:    "--rebase --update-local",
@@ -150,6 +228,14 @@ func TestNewCmdUpdate(t *testing.T) {
 			assert.Equal(t, tt.output.SkipLocal, gotOpts.SkipLocal)
 			assert.Equal(t, tt.output.UpdateLocal, gotOpts.UpdateLocal)
 			assert.Equal(t, tt.output.Rebase, gotOpts.Rebase)
+			assert.Equal(t, tt.output.FastForward, gotOpts.FastForward)
+			assert.Equal(t, tt.output.Autostash, gotOpts.Autostash)
+			assert.Equal(t, tt.output.DryRun, gotOpts.DryRun)
+			assert.Equal(t, tt.output.Force, gotOpts.Force)
+			assert.Equal(t, tt.output.Push, gotOpts.Push)
+			assert.ElementsMatch(t, tt.output.Selectors, gotOpts.Selectors)
+			assert.Equal(t, tt.output.AuthorFilter, gotOpts.AuthorFilter)
+			assert.Equal(t, tt.output.All, gotOpts.All)
 		})
 	}
 }
@@ -171,6 +257,9 @@ func Test_updateRun(t *testing.T) {
 			Branch: func() (string, error) {
 				return "pr-branch", nil
 			},
+			BaseRepo: func() (ghrepo.Interface, error) {
+				return ghrepo.New("OWNER", "REPO"), nil
+			},
 			Finder: shared.NewMockFinder("123", &api.PullRequest{
 				ID:          "123",
 				HeadRefName: "pr-branch",
@@ -380,6 +469,291 @@ func Test_updateRun(t *testing.T) {
 			stdout: "",
 			stderr: "✓ PR branch updated\n✓ local branch updated\n",
 		},
+		{
+			name: "success, tty, --update-local, --fast-forward",
+			input: &UpdateOptions{
+				SelectorArg: "123",
+				Interactive: true,
+				UpdateLocal: true,
+				FastForward: true,
+			},
+			httpStubs: func(t *testing.T, reg *httpmock.Registry) {
+				reg.Register(
+					httpmock.GraphQL(`mutation PullRequestUpdateBranch\b`),
+					httpmock.GraphQLMutation(`{
+						"data": {
+							"updatePullRequestBranch": {
+								"pullRequest": {
+									"id": "123",
+									"headRefOid": "new-head-ref-oid"
+								}
+							}
+						}
+					}`, func(inputs map[string]interface{}) {
+						assert.Equal(t, "123", inputs["pullRequestId"])
+						assert.Equal(t, "head-ref-oid", inputs["expectedHeadOid"])
+						assert.Equal(t, "MERGE", inputs["updateMethod"])
+					}))
+			},
+			cmdStubs: func(cs *run.CommandStubber) {
+				cs.Register(`git rev-parse --verify refs/heads/pr-branch`, 0, "0000000000000000000000000000000000000000")
+				cs.Register(`git fetch origin pr-branch`, 0, "")
+				cs.Register(`git rev-parse refs/heads/pr-branch`, 0, "old-local-oid")
+				cs.Register(`git rev-parse FETCH_HEAD`, 0, "new-fetched-oid")
+				cs.Register(`git merge-base --is-ancestor old-local-oid new-fetched-oid`, 0, "")
+				cs.Register(`git merge --ff-only new-fetched-oid`, 0, "")
+			},
+			stdout: "",
+			stderr: "✓ PR branch updated\n✓ local branch updated\n",
+		},
+		{
+			name: "failure, tty, --update-local, --fast-forward, diverged",
+			input: &UpdateOptions{
+				SelectorArg: "123",
+				Interactive: true,
+				UpdateLocal: true,
+				FastForward: true,
+			},
+			httpStubs: func(t *testing.T, reg *httpmock.Registry) {
+				reg.Register(
+					httpmock.GraphQL(`mutation PullRequestUpdateBranch\b`),
+					httpmock.GraphQLMutation(`{
+						"data": {
+							"updatePullRequestBranch": {
+								"pullRequest": {
+									"id": "123",
+									"headRefOid": "new-head-ref-oid"
+								}
+							}
+						}
+					}`, func(inputs map[string]interface{}) {
+						assert.Equal(t, "123", inputs["pullRequestId"])
+						assert.Equal(t, "head-ref-oid", inputs["expectedHeadOid"])
+						assert.Equal(t, "MERGE", inputs["updateMethod"])
+					}))
+			},
+			cmdStubs: func(cs *run.CommandStubber) {
+				cs.Register(`git rev-parse --verify refs/heads/pr-branch`, 0, "0000000000000000000000000000000000000000")
+				cs.Register(`git fetch origin pr-branch`, 0, "")
+				cs.Register(`git rev-parse refs/heads/pr-branch`, 0, "old-local-oid")
+				cs.Register(`git rev-parse FETCH_HEAD`, 0, "new-fetched-oid")
+				cs.Register(`git merge-base --is-ancestor old-local-oid new-fetched-oid`, 1, "")
+			},
+			wantsErr: "local branch has diverged from the PR branch (local old-loca, remote new-fetc); fast-forward is not possible",
+		},
+		{
+			name: "success, tty, --update-local, --autostash, clean worktree",
+			input: &UpdateOptions{
+				SelectorArg: "123",
+				Interactive: true,
+				UpdateLocal: true,
+				Autostash:   true,
+			},
+			httpStubs: func(t *testing.T, reg *httpmock.Registry) {
+				reg.Register(
+					httpmock.GraphQL(`mutation PullRequestUpdateBranch\b`),
+					httpmock.GraphQLMutation(`{
+						"data": {
+							"updatePullRequestBranch": {
+								"pullRequest": {
+									"id": "123",
+									"headRefOid": "new-head-ref-oid"
+								}
+							}
+						}
+					}`, func(inputs map[string]interface{}) {
+						assert.Equal(t, "123", inputs["pullRequestId"])
+						assert.Equal(t, "head-ref-oid", inputs["expectedHeadOid"])
+						assert.Equal(t, "MERGE", inputs["updateMethod"])
+					}))
+			},
+			cmdStubs: func(cs *run.CommandStubber) {
+				cs.Register(`git rev-parse --verify refs/heads/pr-branch`, 0, "0000000000000000000000000000000000000000")
+				cs.Register(`git status --porcelain`, 0, "")
+				cs.Register(`git pull .+ origin pr-branch`, 0, "")
+			},
+			stdout: "",
+			stderr: "✓ PR branch updated\n✓ local branch updated\n",
+		},
+		{
+			name: "success, tty, --update-local, --autostash, dirty worktree",
+			input: &UpdateOptions{
+				SelectorArg: "123",
+				Interactive: true,
+				UpdateLocal: true,
+				Autostash:   true,
+			},
+			httpStubs: func(t *testing.T, reg *httpmock.Registry) {
+				reg.Register(
+					httpmock.GraphQL(`mutation PullRequestUpdateBranch\b`),
+					httpmock.GraphQLMutation(`{
+						"data": {
+							"updatePullRequestBranch": {
+								"pullRequest": {
+									"id": "123",
+									"headRefOid": "new-head-ref-oid"
+								}
+							}
+						}
+					}`, func(inputs map[string]interface{}) {
+						assert.Equal(t, "123", inputs["pullRequestId"])
+						assert.Equal(t, "head-ref-oid", inputs["expectedHeadOid"])
+						assert.Equal(t, "MERGE", inputs["updateMethod"])
+					}))
+			},
+			cmdStubs: func(cs *run.CommandStubber) {
+				cs.Register(`git rev-parse --verify refs/heads/pr-branch`, 0, "0000000000000000000000000000000000000000")
+				cs.Register(`git status --porcelain`, 0, " M some-file.go\n")
+				cs.Register(`git stash push --include-untracked -m .+`, 0, "")
+				cs.Register(`git pull .+ origin pr-branch`, 0, "")
+				cs.Register(`git stash pop`, 0, "")
+			},
+			stdout: "",
+			stderr: "✓ PR branch updated\n✓ local branch updated\n",
+		},
+		{
+			name: "success, tty, --update-local, --autostash, pop conflict",
+			input: &UpdateOptions{
+				SelectorArg: "123",
+				Interactive: true,
+				UpdateLocal: true,
+				Autostash:   true,
+			},
+			httpStubs: func(t *testing.T, reg *httpmock.Registry) {
+				reg.Register(
+					httpmock.GraphQL(`mutation PullRequestUpdateBranch\b`),
+					httpmock.GraphQLMutation(`{
+						"data": {
+							"updatePullRequestBranch": {
+								"pullRequest": {
+									"id": "123",
+									"headRefOid": "new-head-ref-oid"
+								}
+							}
+						}
+					}`, func(inputs map[string]interface{}) {
+						assert.Equal(t, "123", inputs["pullRequestId"])
+						assert.Equal(t, "head-ref-oid", inputs["expectedHeadOid"])
+						assert.Equal(t, "MERGE", inputs["updateMethod"])
+					}))
+			},
+			cmdStubs: func(cs *run.CommandStubber) {
+				cs.Register(`git rev-parse --verify refs/heads/pr-branch`, 0, "0000000000000000000000000000000000000000")
+				cs.Register(`git status --porcelain`, 0, " M some-file.go\n")
+				cs.Register(`git stash push --include-untracked -m .+`, 0, "")
+				cs.Register(`git pull .+ origin pr-branch`, 0, "")
+				cs.Register(`git stash pop`, 1, "CONFLICT (content): Merge conflict in some-file.go")
+			},
+			stdout: "",
+			stderr: "✓ PR branch updated\n! could not restore stashed changes; run `git stash pop` manually: CONFLICT (content): Merge conflict in some-file.go\n✓ local branch updated\n",
+		},
+		{
+			name: "success, tty, --push, same repo, without AGit support",
+			input: &UpdateOptions{
+				SelectorArg: "123",
+				Interactive: true,
+				Push:        true,
+				Finder: shared.NewMockFinder("123", &api.PullRequest{
+					ID:          "123",
+					Number:      123,
+					HeadRefName: "pr-branch",
+					HeadRefOid:  "head-ref-oid",
+					BaseRefName: "main",
+					BaseRefOid:  "base-ref-oid",
+				}, ghrepo.New("OWNER", "REPO")),
+			},
+			cmdStubs: func(cs *run.CommandStubber) {
+				cs.Register(`git fetch origin pr-branch`, 0, "")
+				cs.Register(`git worktree add --detach \S+ head-ref-oid`, 0, "")
+				cs.Register(`git -C \S+ checkout -B gh-pr-update-123`, 0, "")
+				cs.Register(`git -C \S+ fetch origin main`, 0, "")
+				cs.Register(`git -C \S+ merge FETCH_HEAD`, 0, "")
+				cs.Register(`git ls-remote --symref origin HEAD`, 0, "")
+				cs.Register(`git -C \S+ push origin HEAD:pr-branch`, 0, "")
+				cs.Register(`git worktree remove --force \S+`, 0, "")
+			},
+			stdout: "",
+			stderr: "✓ pushed update\n",
+		},
+		{
+			name: "success, tty, --push, cross-repository (fork)",
+			input: &UpdateOptions{
+				SelectorArg: "123",
+				Interactive: true,
+				Push:        true,
+				Remotes: func() (context.Remotes, error) {
+					return context.Remotes{
+						{
+							Remote: &git.Remote{Name: "origin"},
+							Repo:   ghrepo.New("OWNER", "REPO"),
+						},
+						{
+							Remote: &git.Remote{Name: "fork"},
+							Repo:   ghrepo.New("forker", "REPO"),
+						},
+					}, nil
+				},
+				Finder: shared.NewMockFinder("123", &api.PullRequest{
+					ID:                "123",
+					Number:            123,
+					HeadRefName:       "pr-branch",
+					HeadRefOid:        "head-ref-oid",
+					BaseRefName:       "main",
+					BaseRefOid:        "base-ref-oid",
+					IsCrossRepository: true,
+					HeadRepositoryOwner: struct{ ID, Login string }{
+						Login: "forker",
+					},
+					HeadRepository: struct{ ID, Name string }{
+						Name: "REPO",
+					},
+				}, ghrepo.New("OWNER", "REPO")),
+			},
+			cmdStubs: func(cs *run.CommandStubber) {
+				cs.Register(`git fetch fork pr-branch`, 0, "")
+				cs.Register(`git worktree add --detach \S+ head-ref-oid`, 0, "")
+				cs.Register(`git -C \S+ checkout -B gh-pr-update-123`, 0, "")
+				cs.Register(`git -C \S+ fetch origin main`, 0, "")
+				cs.Register(`git -C \S+ merge FETCH_HEAD`, 0, "")
+				cs.Register(`git ls-remote --symref fork HEAD`, 0, "")
+				cs.Register(`git -C \S+ push fork HEAD:pr-branch`, 0, "")
+				cs.Register(`git worktree remove --force \S+`, 0, "")
+			},
+			stdout: "",
+			stderr: "✓ pushed update\n",
+		},
+		{
+			name: "success, tty, --dry-run, behind",
+			input: &UpdateOptions{
+				SelectorArg: "123",
+				Interactive: true,
+				DryRun:      true,
+				Finder: shared.NewMockFinder("123", &api.PullRequest{
+					ID:               "123",
+					HeadRefName:      "pr-branch",
+					HeadRefOid:       "head-ref-oid",
+					BaseRefOid:       "base-ref-oid",
+					MergeStateStatus: "BEHIND",
+				}, ghrepo.New("OWNER", "REPO")),
+			},
+			stdout: "state: BEHIND\n",
+			stderr: "",
+		},
+		{
+			name: "failure, tty, conflicting, refused without --force",
+			input: &UpdateOptions{
+				SelectorArg: "123",
+				Interactive: true,
+				Finder: shared.NewMockFinder("123", &api.PullRequest{
+					ID:               "123",
+					Number:           123,
+					HeadRefName:      "pr-branch",
+					HeadRefOid:       "head-ref-oid",
+					MergeStateStatus: "CONFLICTING",
+				}, ghrepo.New("OWNER", "REPO")),
+			},
+			wantsErr: "PR #123 has conflicts with its base branch and cannot be updated automatically; run `gh pr checkout 123`, merge or rebase the base branch locally, then push, or pass `--force` to attempt the update anyway",
+		},
 		{
 			name: "failure, tty, --update-local, on a different branch",
 			input: &UpdateOptions{
@@ -499,6 +873,83 @@ func Test_updateRun(t *testing.T) {
 			stdout: "",
 			stderr: "✓ PR branch already up-to-date\n",
 		},
+		{
+			name: "success, no tty behavior, --rebase --fast-forward, no --update-local, update (rebase)",
+			input: &UpdateOptions{
+				SelectorArg: "123",
+				Interactive: false,
+				Rebase:      true,
+				FastForward: true,
+			},
+			httpStubs: func(t *testing.T, reg *httpmock.Registry) {
+				reg.Register(
+					httpmock.GraphQL(`mutation PullRequestUpdateBranch\b`),
+					httpmock.GraphQLMutation(`{
+						"data": {
+							"updatePullRequestBranch": {
+								"pullRequest": {
+									"id": "123",
+									"headRefOid": "new-head-ref-oid"
+								}
+							}
+						}
+					}`, func(inputs map[string]interface{}) {
+						assert.Equal(t, "123", inputs["pullRequestId"])
+						assert.Equal(t, "head-ref-oid", inputs["expectedHeadOid"])
+						assert.Equal(t, "REBASE", inputs["updateMethod"])
+					}))
+			},
+			cmdStubs: func(cs *run.CommandStubber) {
+				cs.Register(`git rev-parse --verify refs/heads/pr-branch`, 0, "0000000000000000000000000000000000000000")
+			},
+			stdout: "",
+			stderr: "✓ PR branch updated\n",
+		},
+		{
+			name: "success, tty, --rebase --fast-forward, prompt (yes), update (rebase)",
+			input: &UpdateOptions{
+				SelectorArg: "123",
+				Interactive: true,
+				Rebase:      true,
+				FastForward: true,
+				Prompter: &prompter.PrompterMock{
+					ConfirmFunc: func(p string, _ bool) (bool, error) {
+						if p == "Update branch locally?" {
+							return true, nil
+						}
+						return false, prompter.NoSuchPromptErr(p)
+					},
+				},
+			},
+			httpStubs: func(t *testing.T, reg *httpmock.Registry) {
+				reg.Register(
+					httpmock.GraphQL(`mutation PullRequestUpdateBranch\b`),
+					httpmock.GraphQLMutation(`{
+						"data": {
+							"updatePullRequestBranch": {
+								"pullRequest": {
+									"id": "123",
+									"headRefOid": "new-head-ref-oid"
+								}
+							}
+						}
+					}`, func(inputs map[string]interface{}) {
+						assert.Equal(t, "123", inputs["pullRequestId"])
+						assert.Equal(t, "head-ref-oid", inputs["expectedHeadOid"])
+						assert.Equal(t, "REBASE", inputs["updateMethod"])
+					}))
+			},
+			cmdStubs: func(cs *run.CommandStubber) {
+				cs.Register(`git rev-parse --verify refs/heads/pr-branch`, 0, "0000000000000000000000000000000000000000")
+				cs.Register(`git fetch origin pr-branch`, 0, "")
+				cs.Register(`git rev-parse refs/heads/pr-branch`, 0, "old-local-oid")
+				cs.Register(`git rev-parse FETCH_HEAD`, 0, "new-fetched-oid")
+				cs.Register(`git merge-base --is-ancestor old-local-oid new-fetched-oid`, 0, "")
+				cs.Register(`git merge --ff-only new-fetched-oid`, 0, "")
+			},
+			stdout: "",
+			stderr: "✓ PR branch updated\n✓ local branch updated\n",
+		},
 		{
 			name: "success, tty, --rebase, update (rebase), with a local branch tracking the remote",
 			input: &UpdateOptions{
@@ -595,6 +1046,10 @@ func Test_updateRun(t *testing.T) {
 				tt.input.Finder = defaultInput().Finder
 			}
 
+			if tt.input.BaseRepo == nil {
+				tt.input.BaseRepo = defaultInput().BaseRepo
+			}
+
 			httpClient := func() (*http.Client, error) { return &http.Client{Transport: reg}, nil }
 
 			tt.input.IO = ios
@@ -614,3 +1069,67 @@ func Test_updateRun(t *testing.T) {
 		})
 	}
 }
+
+func Test_buildBatchSearchQuery(t *testing.T) {
+	tests := []struct {
+		name string
+		opts *UpdateOptions
+		want string
+	}{
+		{
+			name: "--all",
+			opts: &UpdateOptions{All: true},
+			want: "is:open is:pr mergeable-state:behind",
+		},
+		{
+			name: "--author",
+			opts: &UpdateOptions{AuthorFilter: "monalisa"},
+			want: "is:open is:pr author:monalisa",
+		},
+		{
+			name: "--label",
+			opts: &UpdateOptions{LabelFilter: "needs-update"},
+			want: `is:open is:pr label:"needs-update"`,
+		},
+		{
+			name: "--search",
+			opts: &UpdateOptions{SearchFilter: "review:required"},
+			want: "is:open is:pr review:required",
+		},
+		{
+			name: "--all --author, combined",
+			opts: &UpdateOptions{All: true, AuthorFilter: "monalisa"},
+			want: "is:open is:pr mergeable-state:behind author:monalisa",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, buildBatchSearchQuery(tt.opts))
+		})
+	}
+}
+
+func Test_resolveBatch_repoResolutionWithoutSelectors(t *testing.T) {
+	// --author/--label/--search (without --all or explicit selectors) must resolve
+	// the repo on their own, without requiring the current branch to have its own PR.
+	reg := &httpmock.Registry{}
+	defer reg.Verify(t)
+	reg.Register(
+		httpmock.GraphQL(`query \w+\b`),
+		httpmock.StringResponse(`{"data":{"search":{"nodes":[]}}}`))
+
+	opts := &UpdateOptions{
+		AuthorFilter: "monalisa",
+		BaseRepo: func() (ghrepo.Interface, error) {
+			return ghrepo.New("OWNER", "REPO"), nil
+		},
+	}
+
+	httpClient := &http.Client{Transport: reg}
+	apiClient := api.NewClientFromHTTP(httpClient)
+
+	prs, repo, err := resolveBatch(opts, apiClient)
+	assert.NoError(t, err)
+	assert.Equal(t, "OWNER/REPO", ghrepo.FullName(repo))
+	assert.Empty(t, prs)
+}